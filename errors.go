@@ -0,0 +1,97 @@
+package loggenerator
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AttributeGen names a FieldGen so an ErrorProfile can attach it as a
+// structured logrus field alongside the synthetic error's cause chain.
+type AttributeGen struct {
+	Name string
+	Gen  FieldGen
+}
+
+// ErrorProfile configures synthetic error injection for LvlError/LvlFatal
+// emissions: how deep the wrapped cause chain goes, whether to capture a
+// stack trace, and what structured attributes to attach - so error lines
+// look like production errors for Sentry-style grouping and Loki
+// `| json | line_format` pipelines instead of a flat literal message.
+type ErrorProfile struct {
+	// CauseChainDepth is how many times the root cause is wrapped via
+	// fmt.Errorf("%s: %w", ...) before being logged.
+	CauseChainDepth int
+	// IncludeStack captures the call stack via runtime.Callers and attaches
+	// it as a "stack" field rendered as []string.
+	IncludeStack bool
+	// Attributes are attached as top-level logrus.Fields alongside "cause".
+	Attributes []AttributeGen
+	// RootCauses is sampled for the innermost error; WrapLayers is sampled,
+	// once per CauseChainDepth, for each wrapping layer. Both fall back to a
+	// generic message when empty.
+	RootCauses []string
+	WrapLayers []string
+}
+
+// render builds the synthetic error tree and returns its message together
+// with the cause/stack/attribute fields to attach via WithFields.
+func (p ErrorProfile) render() (string, logrus.Fields) {
+	err, causes := p.build()
+
+	fields := make(logrus.Fields, len(p.Attributes)+2)
+	for _, attr := range p.Attributes {
+		fields[attr.Name] = attr.Gen()
+	}
+	fields["cause"] = causes
+	if p.IncludeStack {
+		fields["stack"] = captureStack(3)
+	}
+	return err.Error(), fields
+}
+
+// build wraps a sampled root cause CauseChainDepth times, returning the fully
+// wrapped error and its cause messages ordered outermost-first to match the
+// error's own unwrap order.
+func (p ErrorProfile) build() (error, []string) {
+	root := pickString(p.RootCauses, "unknown failure")
+	causes := []string{root}
+
+	err := errors.New(root)
+	for i := 0; i < p.CauseChainDepth; i++ {
+		layer := pickString(p.WrapLayers, "operation failed")
+		causes = append([]string{layer}, causes...)
+		err = fmt.Errorf("%s: %w", layer, err)
+	}
+	return err, causes
+}
+
+// pickString returns a random element of choices, or fallback when choices
+// is empty.
+func pickString(choices []string, fallback string) string {
+	if len(choices) == 0 {
+		return fallback
+	}
+	return choices[rand.Intn(len(choices))]
+}
+
+// captureStack renders the current call stack, skipping skip frames, as
+// "function (file:line)" entries via runtime.Callers/CallersFrames.
+func captureStack(skip int) []string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	stack := make([]string, 0, n)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+	return stack
+}
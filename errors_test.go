@@ -0,0 +1,61 @@
+package loggenerator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ErrorProfile_render_wrapsCauseChain(t *testing.T) {
+	profile := ErrorProfile{
+		CauseChainDepth: 2,
+		RootCauses:      []string{"connection refused"},
+		WrapLayers:      []string{"query users failed"},
+		Attributes: []AttributeGen{
+			{Name: "request_id", Gen: UUID()},
+		},
+	}
+
+	message, fields := profile.render()
+
+	assert.Equal(t, "query users failed: query users failed: connection refused", message)
+	assert.Equal(t, []string{"query users failed", "query users failed", "connection refused"}, fields["cause"])
+	assert.NotEmpty(t, fields["request_id"])
+	assert.Nil(t, fields["stack"])
+}
+
+func Test_ErrorProfile_render_includesStackWhenEnabled(t *testing.T) {
+	profile := ErrorProfile{IncludeStack: true}
+
+	_, fields := profile.render()
+
+	stack, ok := fields["stack"].([]string)
+	require.True(t, ok)
+	assert.NotEmpty(t, stack)
+}
+
+func Test_LogGenerator_emit_usesErrorProfileForErrorAndFatal(t *testing.T) {
+	var out bytes.Buffer
+
+	generator, errs := New(map[int64]bool{}, LevelRatios{
+		decimal.NewFromInt(0), decimal.NewFromInt(100), decimal.NewFromInt(0),
+		decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0),
+	})
+	require.Empty(t, errs)
+	generator.Logger.Out = &out
+	generator.ErrorProfile = &ErrorProfile{
+		CauseChainDepth: 1,
+		RootCauses:      []string{"disk full"},
+		WrapLayers:      []string{"write failed"},
+	}
+
+	ranges := generator.DeriveDistributionRanges()
+	generator.Output(ranges, 1, 0)
+
+	output := out.String()
+	assert.Contains(t, output, "write failed: disk full")
+	assert.Contains(t, output, `cause="[write failed disk full]"`)
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
+
+	"github.com/robojandro/loggenerator/patterns"
 )
 
 const (
@@ -26,16 +28,44 @@ type LogGenerator struct {
 	Ratios    LevelRatios
 	Ranges    []int64
 	Logger    *logrus.Logger
+	// Shape, when set, enables burst + steady-state traffic shaping in Output
+	// instead of the single fixed delay between lines.
+	Shape *Shape
+	// MessageTemplates holds, per level, the Templates Output samples from to
+	// render structured messages. Levels with no entry fall back to the
+	// original literal message.
+	MessageTemplates map[int64][]Template
+	// Patterns, when set via UsePatterns, take priority over MessageTemplates
+	// for any level they cover.
+	Patterns []patterns.Pattern
+	// Labels, when set, are attached as fields to every emitted entry - used by
+	// StreamSet to tag each stream's lines with its service/instance identity.
+	Labels map[string]string
+	// ErrorProfile, when set, takes priority over Patterns/MessageTemplates for
+	// LvlError and LvlFatal, replacing the literal message with a synthetic
+	// wrapped error and structured cause/stack fields.
+	ErrorProfile *ErrorProfile
+
+	metrics *Metrics
+}
+
+// UsePatterns replaces the generator's hard-coded/templated messages with a
+// weighted sample from the given pattern corpus for any level it covers.
+func (g *LogGenerator) UsePatterns(pats []patterns.Pattern) {
+	g.Patterns = pats
 }
 
 // New creates a LogGenerator, automatically adjusting unspecified ratios so that
 // the total always equals UpperLimit. It returns any validation errors.
 func New(specified map[int64]bool, ratios LevelRatios) (LogGenerator, []error) {
 	generator := LogGenerator{
-		Logger:    logrus.New(),
-		Specified: specified,
-		Ratios:    ratios,
+		Logger:           logrus.New(),
+		Specified:        specified,
+		Ratios:           ratios,
+		MessageTemplates: defaultTemplates(),
+		metrics:          newMetrics(),
 	}
+	generator.Logger.AddHook(&metricsHook{metrics: generator.metrics})
 
 	// validation if there were no specified overrides
 	if len(specified) == 0 {
@@ -46,6 +76,22 @@ func New(specified map[int64]bool, ratios LevelRatios) (LogGenerator, []error) {
 	return generator, nil
 }
 
+// NewJSON is a New shortcut that wires up logrus's JSON formatter so the
+// generator emits structured logs out of the box.
+func NewJSON(specified map[int64]bool, ratios LevelRatios) (LogGenerator, []error) {
+	generator, errs := New(specified, ratios)
+	if len(errs) != 0 {
+		return generator, errs
+	}
+	generator.SetFormatter(&logrus.JSONFormatter{})
+	return generator, nil
+}
+
+// SetFormatter sets the underlying logrus.Logger's formatter.
+func (g LogGenerator) SetFormatter(formatter logrus.Formatter) {
+	g.Logger.Formatter = formatter
+}
+
 // DeriveDistributionRanges take the specified percentages, finds the gaps, and
 // redistruted the unspecified length evenly amongst the unspecified levels
 // with the exception of Fatal, since that should only ever be set by the caller
@@ -98,6 +144,33 @@ func (g LogGenerator) DeriveDistributionRanges() []int64 {
 
 // Output generates logs according to the ratios
 func (g LogGenerator) Output(ranges []int64, outputLimit int, delay int64) map[int64]int64 {
+	seed := rand.NewSource(time.Now().UnixNano())
+	rander := rand.New(seed)
+	outputCounts := make(map[int64]int64, 6)
+
+	sleepDuration := time.Millisecond * time.Duration(delay)
+	if g.Shape != nil {
+		sleepDuration = g.Shape.sleepInterval(delay)
+	}
+
+	for i := 0; i <= outputLimit; i++ {
+		time.Sleep(sleepDuration)
+		level, ok := pickLevel(ranges, rander)
+		if !ok {
+			continue
+		}
+		if g.Shape == nil || g.Shape.allow(level) {
+			outputCounts[level]++
+			g.emit(level)
+		}
+	}
+	return outputCounts
+}
+
+// pickLevel samples a single log level from ranges, the distribution
+// produced by DeriveDistributionRanges. It reports false on the
+// (statistically near-impossible) draw that lands outside every range.
+func pickLevel(ranges []int64, rander *rand.Rand) (int64, bool) {
 	rangeLimit := int64(60000)
 	fatalLow := rangeLimit - ranges[LvlFatal]
 	errorLow := fatalLow - ranges[LvlError]
@@ -106,34 +179,86 @@ func (g LogGenerator) Output(ranges []int64, outputLimit int, delay int64) map[i
 	debugLow := infoLow - ranges[LvlDebug]
 	// traceLow := debugLow - ranges[LvlTrace] is unneccesary as it should always be 0
 
-	seed := rand.NewSource(time.Now().UnixNano())
-	rander := rand.New(seed)
-	outputCounts := make(map[int64]int64, 6)
-	for i := 0; i <= outputLimit; i++ {
-		time.Sleep(time.Millisecond * time.Duration(delay))
-		randOut := rander.Int63n(int64(rangeLimit))
-		switch {
-		case randOut >= fatalLow && randOut < rangeLimit:
-			outputCounts[LvlFatal]++
-			g.Logger.Fatalf("fatal level message")
-		case randOut >= errorLow && randOut < fatalLow:
-			outputCounts[LvlError]++
-			g.Logger.Errorf("error level message")
-		case randOut >= warnLow && randOut < errorLow:
-			outputCounts[LvlWarn]++
-			g.Logger.Warnf("warn level message")
-		case randOut >= infoLow && randOut < warnLow:
-			outputCounts[LvlInfo]++
-			g.Logger.Infof("info level message")
-		case randOut >= debugLow && randOut < infoLow:
-			outputCounts[LvlDebug]++
-			g.Logger.Debugf("debug level message")
-		case randOut > 0 && randOut < debugLow:
-			outputCounts[LvlTrace]++
-			g.Logger.Tracef("trace level message")
+	randOut := rander.Int63n(rangeLimit)
+	switch {
+	case randOut >= fatalLow && randOut < rangeLimit:
+		return LvlFatal, true
+	case randOut >= errorLow && randOut < fatalLow:
+		return LvlError, true
+	case randOut >= warnLow && randOut < errorLow:
+		return LvlWarn, true
+	case randOut >= infoLow && randOut < warnLow:
+		return LvlInfo, true
+	case randOut >= debugLow && randOut < infoLow:
+		return LvlDebug, true
+	case randOut > 0 && randOut < debugLow:
+		return LvlTrace, true
+	}
+	return 0, false
+}
+
+// emit renders the given level's message - from ErrorProfile for LvlError and
+// LvlFatal when one is set, otherwise from a sampled Pattern, then a sampled
+// Template, and finally the original literal - and logs it with any generated
+// fields attached.
+func (g LogGenerator) emit(level int64) {
+	start := time.Now()
+
+	var message string
+	var fields logrus.Fields
+
+	if g.ErrorProfile != nil && (level == LvlError || level == LvlFatal) {
+		message, fields = g.ErrorProfile.render()
+	} else {
+		if len(g.Patterns) > 0 {
+			if pattern, ok := patterns.Sample(g.Patterns, level); ok {
+				message = pattern.Render()
+			}
+		}
+		if message == "" {
+			if templates := g.MessageTemplates[level]; len(templates) > 0 {
+				message, fields = templates[rand.Intn(len(templates))].Render()
+			} else {
+				message = defaultMessages[level]
+			}
 		}
 	}
-	return outputCounts
+
+	// message is arbitrary, generated content (template/pattern/error-chain
+	// text) and must never be passed as a format string - a literal "%" in it
+	// would otherwise be parsed as a verb.
+	entry := g.Logger.WithFields(g.withLabels(fields))
+	switch level {
+	case LvlFatal:
+		entry.Fatal(message)
+	case LvlError:
+		entry.Error(message)
+	case LvlWarn:
+		entry.Warn(message)
+	case LvlInfo:
+		entry.Info(message)
+	case LvlDebug:
+		entry.Debug(message)
+	case LvlTrace:
+		entry.Trace(message)
+	}
+	g.metrics.observeDuration(time.Since(start))
+}
+
+// withLabels merges g.Labels under fields so a stream's identity always
+// accompanies its message-specific fields.
+func (g LogGenerator) withLabels(fields logrus.Fields) logrus.Fields {
+	if len(g.Labels) == 0 {
+		return fields
+	}
+	merged := make(logrus.Fields, len(g.Labels)+len(fields))
+	for k, v := range g.Labels {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
 }
 
 // validateLevelRatios ensures each ratio is within [0, UpperLimit] and that the sum
@@ -0,0 +1,153 @@
+package loggenerator
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// levelNames maps the Lvl... constants to their Prometheus label value, in
+// the same order as the Lvl... iota.
+var levelNames = [...]string{"fatal", "error", "warn", "info", "debug", "trace"}
+
+// histogramBuckets are the upper bounds, in seconds, of the
+// loggenerator_emit_duration_seconds histogram.
+var histogramBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+// logrusLevelToLvl maps logrus's own Level to the Lvl... constants, so the
+// metricsHook can attribute a fired entry to the right counter.
+var logrusLevelToLvl = map[logrus.Level]int64{
+	logrus.FatalLevel: LvlFatal,
+	logrus.ErrorLevel: LvlError,
+	logrus.WarnLevel:  LvlWarn,
+	logrus.InfoLevel:  LvlInfo,
+	logrus.DebugLevel: LvlDebug,
+	logrus.TraceLevel: LvlTrace,
+}
+
+// Metrics holds rolling per-level line/byte counters and an emit-duration
+// histogram for a LogGenerator, and serves them in Prometheus
+// text-exposition format via ServeHTTP.
+type Metrics struct {
+	mu            sync.Mutex
+	lines         map[int64]int64
+	bytes         map[int64]int64
+	bucketCounts  []int64
+	durationSum   float64
+	durationCount int64
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		lines:        make(map[int64]int64, len(levelNames)),
+		bytes:        make(map[int64]int64, len(levelNames)),
+		bucketCounts: make([]int64, len(histogramBuckets)),
+	}
+}
+
+// observeDuration folds an emit's duration directly into the fixed histogram
+// buckets and running sum/count, so memory stays flat regardless of how long
+// the generator runs.
+func (m *Metrics) observeDuration(d time.Duration) {
+	seconds := d.Seconds()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.durationSum += seconds
+	m.durationCount++
+	for i, bound := range histogramBuckets {
+		if seconds <= bound {
+			m.bucketCounts[i]++
+		}
+	}
+}
+
+// record attributes a rendered line's byte length to level's counters.
+func (m *Metrics) record(level int64, byteLen int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lines[level]++
+	m.bytes[level] += int64(byteLen)
+}
+
+// metricsHook is a logrus.Hook that records each fired entry's level and
+// rendered byte length. Using a hook (rather than wrapping Logger.Out) keeps
+// the level scoped to the entry logrus hands Fire, instead of a field shared
+// across concurrent calls on the same LogGenerator.
+type metricsHook struct {
+	metrics *Metrics
+}
+
+func (h *metricsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *metricsHook) Fire(entry *logrus.Entry) error {
+	level, ok := logrusLevelToLvl[entry.Level]
+	if !ok {
+		return nil
+	}
+	rendered, err := entry.Logger.Formatter.Format(entry)
+	if err != nil {
+		return nil
+	}
+	h.metrics.record(level, len(rendered))
+	return nil
+}
+
+// Metrics returns the generator's rolling per-level counters and
+// emit-duration histogram.
+func (g LogGenerator) Metrics() *Metrics {
+	return g.metrics
+}
+
+// StartMetricsServer runs an http.Server exposing Metrics() at addr in the
+// background and returns immediately. The caller owns the returned server
+// and may call Shutdown/Close on it.
+func (g LogGenerator) StartMetricsServer(addr string) *http.Server {
+	server := &http.Server{
+		Addr:    addr,
+		Handler: g.Metrics(),
+	}
+	go func() {
+		_ = server.ListenAndServe()
+	}()
+	return server
+}
+
+// ServeHTTP renders the counters and histogram in Prometheus
+// text-exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP loggenerator_lines_total Total log lines emitted per level.")
+	fmt.Fprintln(w, "# TYPE loggenerator_lines_total counter")
+	for level, name := range levelNames {
+		fmt.Fprintf(w, "loggenerator_lines_total{level=%q} %d\n", name, m.lines[int64(level)])
+	}
+
+	fmt.Fprintln(w, "# HELP loggenerator_bytes_total Total bytes of rendered log lines emitted per level.")
+	fmt.Fprintln(w, "# TYPE loggenerator_bytes_total counter")
+	for level, name := range levelNames {
+		fmt.Fprintf(w, "loggenerator_bytes_total{level=%q} %d\n", name, m.bytes[int64(level)])
+	}
+
+	fmt.Fprintln(w, "# HELP loggenerator_emit_duration_seconds Time to render and emit a single log line.")
+	fmt.Fprintln(w, "# TYPE loggenerator_emit_duration_seconds histogram")
+	m.writeHistogram(w)
+}
+
+func (m *Metrics) writeHistogram(w io.Writer) {
+	for i, bound := range histogramBuckets {
+		fmt.Fprintf(w, "loggenerator_emit_duration_seconds_bucket{le=\"%g\"} %d\n", bound, m.bucketCounts[i])
+	}
+	fmt.Fprintf(w, "loggenerator_emit_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.durationCount)
+	fmt.Fprintf(w, "loggenerator_emit_duration_seconds_sum %g\n", m.durationSum)
+	fmt.Fprintf(w, "loggenerator_emit_duration_seconds_count %d\n", m.durationCount)
+}
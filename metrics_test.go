@@ -0,0 +1,83 @@
+package loggenerator
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Metrics_Output_countsLinesAndBytes(t *testing.T) {
+	generator, errs := New(map[int64]bool{}, LevelRatios{
+		decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0),
+		decimal.NewFromInt(100), decimal.NewFromInt(0), decimal.NewFromInt(0),
+	})
+	require.Len(t, errs, 0)
+
+	ranges := generator.DeriveDistributionRanges()
+	outputCounts := generator.Output(ranges, 5, 0)
+
+	metrics := generator.Metrics()
+	metrics.mu.Lock()
+	lines := metrics.lines[LvlInfo]
+	bytes := metrics.bytes[LvlInfo]
+	metrics.mu.Unlock()
+
+	assert.Equal(t, outputCounts[LvlInfo], lines)
+	assert.Positive(t, bytes)
+}
+
+func Test_Metrics_ServeHTTP_exposesPrometheusFormat(t *testing.T) {
+	generator, errs := New(map[int64]bool{}, LevelRatios{
+		decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0),
+		decimal.NewFromInt(100), decimal.NewFromInt(0), decimal.NewFromInt(0),
+	})
+	require.Len(t, errs, 0)
+
+	ranges := generator.DeriveDistributionRanges()
+	outputCounts := generator.Output(ranges, 3, 0)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/metrics", nil)
+	generator.Metrics().ServeHTTP(recorder, request)
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, fmt.Sprintf(`loggenerator_lines_total{level="info"} %d`, outputCounts[LvlInfo]))
+	assert.Contains(t, body, `loggenerator_bytes_total{level="info"}`)
+	assert.Contains(t, body, fmt.Sprintf("loggenerator_emit_duration_seconds_count %d", outputCounts[LvlInfo]))
+}
+
+// Test_Metrics_Output_concurrentCallsDontRace exercises the scenario Shape's
+// per-level mutexes were designed to support: multiple goroutines calling
+// Output on the same LogGenerator. Run with -race to confirm metrics
+// attribution has no shared mutable per-call state.
+func Test_Metrics_Output_concurrentCallsDontRace(t *testing.T) {
+	generator, errs := New(map[int64]bool{}, LevelRatios{
+		decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0),
+		decimal.NewFromInt(100), decimal.NewFromInt(0), decimal.NewFromInt(0),
+	})
+	require.Len(t, errs, 0)
+	generator.Logger.Out = io.Discard
+
+	ranges := generator.DeriveDistributionRanges()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generator.Output(ranges, 25, 0)
+		}()
+	}
+	wg.Wait()
+
+	metrics := generator.Metrics()
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	assert.EqualValues(t, 8*26, metrics.lines[LvlInfo])
+}
@@ -0,0 +1,112 @@
+package patterns
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// similarityThreshold is the minimum token-overlap ratio for a log line to be
+// merged into an existing cluster rather than starting a new one.
+const similarityThreshold = 0.5
+
+type cluster struct {
+	tokens []string
+	count  int
+}
+
+// MinePatterns runs a simple fixed-depth Drain over logs: lines are grouped
+// by token count then first token, and a line joins the first cluster in its
+// group whose template is at least similarityThreshold similar to it token by
+// token (diverging positions become "<*>"), or starts a new cluster otherwise.
+// The resulting Patterns are all tagged LvlInfo and weighted by cluster size,
+// so callers can feed a real log file and get back synthetic traffic that
+// mimics its structure.
+func MinePatterns(logs io.Reader) ([]Pattern, error) {
+	groups := map[int]map[string][]*cluster{}
+
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		tokens := strings.Fields(line)
+
+		byFirst, ok := groups[len(tokens)]
+		if !ok {
+			byFirst = map[string][]*cluster{}
+			groups[len(tokens)] = byFirst
+		}
+
+		matched := false
+		for _, c := range byFirst[tokens[0]] {
+			if similarity(c.tokens, tokens) >= similarityThreshold {
+				merge(c.tokens, tokens)
+				c.count++
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			byFirst[tokens[0]] = append(byFirst[tokens[0]], &cluster{
+				tokens: append([]string(nil), tokens...),
+				count:  1,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, byFirst := range groups {
+		for _, clusters := range byFirst {
+			for _, c := range clusters {
+				total += c.count
+			}
+		}
+	}
+
+	var mined []Pattern
+	for _, byFirst := range groups {
+		for _, clusters := range byFirst {
+			for _, c := range clusters {
+				weight := 1.0
+				if total > 0 {
+					weight = float64(c.count) / float64(total)
+				}
+				mined = append(mined, Pattern{Level: LvlInfo, Weight: weight, Tokens: c.tokens})
+			}
+		}
+	}
+	return mined, nil
+}
+
+// similarity reports the fraction of equal-length token positions that either
+// match exactly or are already a wildcard.
+func similarity(template, tokens []string) float64 {
+	if len(template) != len(tokens) {
+		return 0
+	}
+	if len(template) == 0 {
+		return 1
+	}
+	matches := 0
+	for i := range template {
+		if template[i] == tokens[i] || template[i] == "<*>" {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(template))
+}
+
+// merge widens template in place, turning any position that diverges from
+// tokens into a wildcard.
+func merge(template, tokens []string) {
+	for i := range template {
+		if template[i] != tokens[i] {
+			template[i] = "<*>"
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MinePatterns(t *testing.T) {
+	logs := `GET /api/users 200 12ms
+GET /api/orders 200 45ms
+GET /api/users 404 3ms
+POST /api/login 200 90ms
+`
+	mined, err := MinePatterns(strings.NewReader(logs))
+	require.NoError(t, err)
+	require.NotEmpty(t, mined)
+
+	var getCluster *Pattern
+	for i := range mined {
+		if mined[i].Tokens[0] == "GET" {
+			getCluster = &mined[i]
+			break
+		}
+	}
+	require.NotNil(t, getCluster, "expected a cluster starting with GET")
+	assert.Contains(t, getCluster.Tokens, "<*>")
+	assert.Equal(t, int64(LvlInfo), getCluster.Level)
+}
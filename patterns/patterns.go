@@ -0,0 +1,122 @@
+// Package patterns implements a Drain-style pattern corpus for loggenerator:
+// templates made of literal tokens interleaved with "<*>" wildcards, loaded
+// from a file, mined from real logs, or drawn from a small built-in corpus.
+package patterns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Level constants mirror loggenerator's Lvl* values. They're redefined here
+// rather than imported to avoid an import cycle, since loggenerator itself
+// depends on this package.
+const (
+	LvlFatal = iota
+	LvlError
+	LvlWarn
+	LvlInfo
+	LvlDebug
+	LvlTrace
+)
+
+var levelNames = map[string]int64{
+	"fatal": LvlFatal,
+	"error": LvlError,
+	"warn":  LvlWarn,
+	"info":  LvlInfo,
+	"debug": LvlDebug,
+	"trace": LvlTrace,
+}
+
+// Pattern is a Drain-style template: Tokens interleaved with the "<*>"
+// wildcard, sampled for a given Level weighted by Weight.
+type Pattern struct {
+	Level  int64
+	Weight float64
+	Tokens []string
+}
+
+// LoadPatterns reads one Drain-style pattern per line from r. Each line is a
+// whitespace-separated list of tokens, with "<*>" standing in for a wildcard
+// whose type is inferred from its neighbors, or "<*:kind>" (kind one of
+// numeric, ip, uuid, path, string) to say explicitly what it renders as,
+// optionally prefixed with "level=info weight=0.4 " metadata. Blank lines and
+// lines starting with "#" are skipped. Lines with no level= prefix default to
+// LvlInfo; lines with no weight= prefix default to a weight of 1.
+func LoadPatterns(r io.Reader) ([]Pattern, error) {
+	var loaded []Pattern
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		level := int64(LvlInfo)
+		weight := 1.0
+
+		idx := 0
+		for idx < len(fields) {
+			if rest, ok := strings.CutPrefix(fields[idx], "level="); ok {
+				parsed, ok := levelNames[rest]
+				if !ok {
+					return nil, fmt.Errorf("patterns: line %d: unknown level %q", lineNum, rest)
+				}
+				level = parsed
+				idx++
+				continue
+			}
+			if rest, ok := strings.CutPrefix(fields[idx], "weight="); ok {
+				parsed, err := strconv.ParseFloat(rest, 64)
+				if err != nil {
+					return nil, fmt.Errorf("patterns: line %d: invalid weight %q: %w", lineNum, rest, err)
+				}
+				weight = parsed
+				idx++
+				continue
+			}
+			break
+		}
+
+		tokens := fields[idx:]
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("patterns: line %d: pattern has no tokens", lineNum)
+		}
+
+		loaded = append(loaded, Pattern{Level: level, Weight: weight, Tokens: tokens})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return loaded, nil
+}
+
+// builtinCorpus hints every wildcard whose neighbors wouldn't otherwise give
+// wildcardKind the right answer (e.g. a path sitting next to a status code
+// that merely looks numeric) - see the "<*:kind>" syntax documented on
+// LoadPatterns.
+const builtinCorpus = `
+level=info weight=0.4 GET <*:path> 200 <*:numeric> ms
+level=info weight=0.3 user <*:string> logged in from <*:ip>
+level=warn weight=0.5 disk usage at <*:numeric> percent on <*:string>
+level=error weight=0.6 failed to connect to <*:ip> after <*:numeric> attempts
+level=debug weight=0.3 cache miss for key <*>
+`
+
+// Builtin returns the small built-in pattern corpus, for callers who want a
+// LogGenerator to produce pattern-shaped output without supplying their own.
+func Builtin() []Pattern {
+	builtin, err := LoadPatterns(strings.NewReader(builtinCorpus))
+	if err != nil {
+		panic(fmt.Sprintf("patterns: invalid builtin corpus: %v", err))
+	}
+	return builtin
+}
@@ -0,0 +1,78 @@
+package patterns
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadPatterns(t *testing.T) {
+	input := `
+# a comment, and a blank line above
+level=error weight=0.6 failed to connect to <*> after <*> attempts
+level=warn user <*> not found
+no metadata pattern here
+`
+	loaded, err := LoadPatterns(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, loaded, 3)
+
+	assert.Equal(t, int64(LvlError), loaded[0].Level)
+	assert.Equal(t, 0.6, loaded[0].Weight)
+	assert.Equal(t, []string{"failed", "to", "connect", "to", "<*>", "after", "<*>", "attempts"}, loaded[0].Tokens)
+
+	assert.Equal(t, int64(LvlWarn), loaded[1].Level)
+
+	// defaults when metadata is absent
+	assert.Equal(t, int64(LvlInfo), loaded[2].Level)
+	assert.Equal(t, 1.0, loaded[2].Weight)
+}
+
+func Test_LoadPatterns_unknownLevel(t *testing.T) {
+	_, err := LoadPatterns(strings.NewReader("level=verbose something happened"))
+	assert.Error(t, err)
+}
+
+func Test_Builtin(t *testing.T) {
+	builtin := Builtin()
+	assert.NotEmpty(t, builtin)
+}
+
+// Test_Builtin_Render_fieldsMatchExpectedShape renders every hinted wildcard
+// in the actual Builtin() corpus (not a hand-built token list) and checks
+// its rendered value has the shape its hint promises, catching the case
+// where a pattern's wildcard is rendered as the wrong kind.
+func Test_Builtin_Render_fieldsMatchExpectedShape(t *testing.T) {
+	pathRe := regexp.MustCompile(`^/\S+$`)
+	ipRe := regexp.MustCompile(`^\d{1,3}(\.\d{1,3}){3}$`)
+	numericRe := regexp.MustCompile(`^\d+$`)
+	uuidRe := regexp.MustCompile(`^[0-9a-f]+(-[0-9a-f]+)+$`)
+
+	for _, pattern := range Builtin() {
+		for i, token := range pattern.Tokens {
+			kind, ok := parseWildcard(token)
+			if !ok || kind == "" {
+				continue
+			}
+
+			for n := 0; n < 5; n++ {
+				fields := strings.Fields(pattern.Render())
+				require.Len(t, fields, len(pattern.Tokens), "pattern %q", strings.Join(pattern.Tokens, " "))
+
+				switch kind {
+				case "path":
+					assert.Regexp(t, pathRe, fields[i])
+				case "ip":
+					assert.Regexp(t, ipRe, fields[i])
+				case "numeric":
+					assert.Regexp(t, numericRe, fields[i])
+				case "uuid":
+					assert.Regexp(t, uuidRe, fields[i])
+				}
+			}
+		}
+	}
+}
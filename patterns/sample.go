@@ -0,0 +1,144 @@
+package patterns
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Sample picks a Pattern for level, weighted by Weight (falling back to a
+// uniform pick when every candidate's weight is zero). The second return
+// value is false when no Pattern matches level.
+func Sample(pats []Pattern, level int64) (Pattern, bool) {
+	var candidates []Pattern
+	for _, p := range pats {
+		if p.Level == level {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return Pattern{}, false
+	}
+
+	total := 0.0
+	for _, p := range candidates {
+		total += p.Weight
+	}
+	if total <= 0 {
+		return candidates[rand.Intn(len(candidates))], true
+	}
+
+	target := rand.Float64() * total
+	cumulative := 0.0
+	for _, p := range candidates {
+		cumulative += p.Weight
+		if target < cumulative {
+			return p, true
+		}
+	}
+	return candidates[len(candidates)-1], true
+}
+
+// Render instantiates every wildcard in the pattern and joins the result
+// into a single message. A wildcard is either a bare "<*>", whose type is
+// inferred from the surrounding tokens (the only option available to mined
+// patterns, which carry no type information), or a hinted "<*:kind>", whose
+// kind is used directly - letting hand-authored corpora like Builtin() say
+// what a field actually is instead of hoping its neighbors look the part.
+func (p Pattern) Render() string {
+	rendered := make([]string, len(p.Tokens))
+	for i, token := range p.Tokens {
+		if hint, ok := parseWildcard(token); ok {
+			kind := hint
+			if kind == "" {
+				kind = wildcardKind(p.Tokens, i)
+			}
+			rendered[i] = instantiate(kind)
+		} else {
+			rendered[i] = token
+		}
+	}
+	return strings.Join(rendered, " ")
+}
+
+var (
+	reDigits   = regexp.MustCompile(`^\d+$`)
+	reIPFrag   = regexp.MustCompile(`^\d{1,3}(\.\d{1,3}){1,3}$`)
+	reHexFrag  = regexp.MustCompile(`^[0-9a-fA-F]+(-[0-9a-fA-F]+)+$`)
+	reWildcard = regexp.MustCompile(`^<\*(?::([a-z]+))?>$`)
+)
+
+// parseWildcard reports whether token is a wildcard ("<*>" or "<*:kind>"),
+// and if so its kind hint (empty for a bare "<*>").
+func parseWildcard(token string) (kind string, ok bool) {
+	match := reWildcard.FindStringSubmatch(token)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// wildcardKind infers what an unhinted wildcard at idx stands for by looking
+// at its neighboring tokens: numeric if adjacent to digits, an IP if
+// adjacent to dotted-quad-shaped fragments, a UUID if flanked by hyphenated
+// hex, and a quoted string otherwise.
+func wildcardKind(tokens []string, idx int) string {
+	var neighbors []string
+	if idx > 0 {
+		neighbors = append(neighbors, tokens[idx-1])
+	}
+	if idx < len(tokens)-1 {
+		neighbors = append(neighbors, tokens[idx+1])
+	}
+
+	for _, n := range neighbors {
+		if reHexFrag.MatchString(n) {
+			return "uuid"
+		}
+	}
+	for _, n := range neighbors {
+		if reIPFrag.MatchString(n) {
+			return "ip"
+		}
+	}
+	for _, n := range neighbors {
+		if reDigits.MatchString(n) {
+			return "numeric"
+		}
+	}
+	return "string"
+}
+
+var corpusWords = []string{
+	"timeout", "unavailable", "ok", "pending", "retrying", "degraded", "stale", "queued",
+}
+
+var corpusPaths = []string{
+	"/api/users", "/api/orders", "/api/health", "/api/login", "/api/payments",
+}
+
+func instantiate(kind string) string {
+	switch kind {
+	case "numeric":
+		return strconv.Itoa(rand.Intn(10000))
+	case "ip":
+		return fmt.Sprintf("%d.%d.%d.%d", rand.Intn(256), rand.Intn(256), rand.Intn(256), rand.Intn(256))
+	case "uuid":
+		return randomUUID()
+	case "path":
+		return corpusPaths[rand.Intn(len(corpusPaths))]
+	default:
+		return fmt.Sprintf("%q", corpusWords[rand.Intn(len(corpusWords))])
+	}
+}
+
+func randomUUID() string {
+	buf := make([]byte, 16)
+	_, _ = crand.Read(buf)
+	buf[6] = (buf[6] & 0x0f) | 0x40
+	buf[8] = (buf[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:16])
+}
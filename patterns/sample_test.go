@@ -0,0 +1,69 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sample_filtersByLevel(t *testing.T) {
+	pats := []Pattern{
+		{Level: LvlInfo, Weight: 1, Tokens: []string{"info", "pattern"}},
+		{Level: LvlError, Weight: 1, Tokens: []string{"error", "pattern"}},
+	}
+
+	for i := 0; i < 20; i++ {
+		picked, ok := Sample(pats, LvlError)
+		assert.True(t, ok)
+		assert.Equal(t, int64(LvlError), picked.Level)
+	}
+
+	_, ok := Sample(pats, LvlWarn)
+	assert.False(t, ok)
+}
+
+func Test_Pattern_Render_wildcardKinds(t *testing.T) {
+	pattern := Pattern{
+		Level: LvlInfo,
+		Tokens: []string{
+			"GET", "200", "<*>", "ms", "from", "<*>", "192.168.0.1", "id", "<*>", "ab12-cd34",
+		},
+	}
+
+	rendered := strings.Fields(pattern.Render())
+	require := assert.New(t)
+	require.Len(rendered, 10)
+	// numeric, adjacent to "200" or "ms"
+	require.Regexp(`^\d+$`, rendered[2])
+	// ip, adjacent to the dotted-quad literal
+	require.Regexp(`^\d{1,3}(\.\d{1,3}){3}$`, rendered[5])
+	// uuid, adjacent to hyphenated hex
+	require.Regexp(`^[0-9a-f]+(-[0-9a-f]+)+$`, rendered[8])
+}
+
+func Test_Pattern_Render_explicitHintOverridesAdjacency(t *testing.T) {
+	pattern := Pattern{
+		Level: LvlInfo,
+		// bare adjacency would read this wildcard as numeric (next to "200"),
+		// but the hint says it's a path - and the hint must win.
+		Tokens: []string{"GET", "<*:path>", "200"},
+	}
+
+	rendered := strings.Fields(pattern.Render())
+	assert.Len(t, rendered, 3)
+	assert.Regexp(t, `^/\S+$`, rendered[1])
+}
+
+func Test_parseWildcard(t *testing.T) {
+	kind, ok := parseWildcard("<*>")
+	assert.True(t, ok)
+	assert.Empty(t, kind)
+
+	kind, ok = parseWildcard("<*:ip>")
+	assert.True(t, ok)
+	assert.Equal(t, "ip", kind)
+
+	_, ok = parseWildcard("not-a-wildcard")
+	assert.False(t, ok)
+}
@@ -0,0 +1,32 @@
+package loggenerator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robojandro/loggenerator/patterns"
+)
+
+func Test_UsePatterns_overridesMessagesForCoveredLevels(t *testing.T) {
+	generator, errs := New(map[int64]bool{}, LevelRatios{
+		decimal.NewFromInt(0), decimal.NewFromInt(10), decimal.NewFromInt(20),
+		decimal.NewFromInt(50), decimal.NewFromInt(20), decimal.NewFromInt(0),
+	})
+	require.Len(t, errs, 0)
+	var out bytes.Buffer
+	generator.Logger.Out = &out
+
+	generator.UsePatterns([]patterns.Pattern{
+		{Level: LvlInfo, Weight: 1, Tokens: []string{"patterned", "message"}},
+	})
+
+	ranges := generator.DeriveDistributionRanges()
+	outputCounts := generator.Output(ranges, 200, 0)
+
+	assert.Greater(t, outputCounts[LvlInfo], int64(0))
+	assert.Contains(t, out.String(), `msg="patterned message"`)
+}
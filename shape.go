@@ -0,0 +1,90 @@
+package loggenerator
+
+import (
+	"sync"
+	"time"
+)
+
+// LevelShape configures burst-then-throttle behavior for a single level within a
+// Shape, modeled after the sampling cores in zap/zerolog: the first Burst events
+// in a Period pass through immediately, and only every Thereafter-th event after
+// the burst emits.
+type LevelShape struct {
+	Burst      int
+	Thereafter int
+}
+
+// Shape configures burst + steady-state traffic shaping for Output. When set on a
+// LogGenerator it replaces the single fixed delay with per-level burst windows plus
+// an overall LinesPerSecond target for the steady state.
+type Shape struct {
+	// Period is the window over which each level's Burst/Thereafter counters reset.
+	Period time.Duration
+	// Levels holds the per-level Burst/Thereafter configuration, keyed by the Lvl... constants.
+	Levels map[int64]LevelShape
+	// LinesPerSecond caps the overall steady-state emit rate.
+	LinesPerSecond int
+
+	counters map[int64]*levelCounter
+}
+
+// levelCounter tracks a single level's burst window and is safe for concurrent use
+// so Output can later be called from multiple goroutines.
+type levelCounter struct {
+	mu          sync.Mutex
+	count       int
+	windowStart time.Time
+}
+
+// NewShape builds a Shape with its per-level counters initialized and ready for use.
+func NewShape(period time.Duration, levels map[int64]LevelShape, linesPerSecond int) *Shape {
+	counters := make(map[int64]*levelCounter, len(levels))
+	for lvl := range levels {
+		counters[lvl] = &levelCounter{}
+	}
+	return &Shape{
+		Period:         period,
+		Levels:         levels,
+		LinesPerSecond: linesPerSecond,
+		counters:       counters,
+	}
+}
+
+// allow reports whether the given level should emit this tick, honoring its burst
+// and post-burst thereafter configuration. Levels with no configured LevelShape
+// always emit.
+func (s *Shape) allow(level int64) bool {
+	counter, ok := s.counters[level]
+	if !ok {
+		return true
+	}
+	ls := s.Levels[level]
+	thereafter := ls.Thereafter
+	if thereafter <= 0 {
+		thereafter = 1
+	}
+
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(counter.windowStart) >= s.Period {
+		counter.windowStart = now
+		counter.count = 0
+	}
+	counter.count++
+
+	if counter.count <= ls.Burst {
+		return true
+	}
+	return (counter.count-ls.Burst)%thereafter == 0
+}
+
+// sleepInterval returns how long Output should sleep between emit attempts: the
+// fixed delay by default, or the interval implied by LinesPerSecond when set.
+func (s *Shape) sleepInterval(delay int64) time.Duration {
+	if s.LinesPerSecond > 0 {
+		return time.Second / time.Duration(s.LinesPerSecond)
+	}
+	return time.Millisecond * time.Duration(delay)
+}
@@ -0,0 +1,40 @@
+package loggenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Shape_allow(t *testing.T) {
+	shape := NewShape(time.Minute, map[int64]LevelShape{
+		LvlInfo: {Burst: 2, Thereafter: 3},
+	}, 0)
+
+	// burst: first two events always pass
+	assert.True(t, shape.allow(LvlInfo))
+	assert.True(t, shape.allow(LvlInfo))
+
+	// past the burst, only every 3rd event passes
+	assert.False(t, shape.allow(LvlInfo)) // count 3
+	assert.False(t, shape.allow(LvlInfo)) // count 4
+	assert.True(t, shape.allow(LvlInfo))  // count 5, (5-2)%3==0
+
+	// levels with no configured LevelShape always emit
+	assert.True(t, shape.allow(LvlWarn))
+}
+
+func Test_Shape_allow_windowReset(t *testing.T) {
+	shape := NewShape(time.Millisecond, map[int64]LevelShape{
+		LvlError: {Burst: 1, Thereafter: 10},
+	}, 0)
+
+	assert.True(t, shape.allow(LvlError))
+	assert.False(t, shape.allow(LvlError))
+
+	time.Sleep(2 * time.Millisecond)
+
+	// window reset, burst allowance is back
+	assert.True(t, shape.allow(LvlError))
+}
@@ -0,0 +1,118 @@
+package loggenerator
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Stream describes one logical log-emitting service within a StreamSet: its
+// own labels, level ratios, optional message templates, and target rate.
+type Stream struct {
+	Name   string
+	Labels map[string]string
+	Ratios LevelRatios
+	// MessageTemplates, when set, overrides the default templates for this
+	// stream only; a nil map leaves the generator's defaults in place.
+	MessageTemplates map[int64][]Template
+	LinesPerSecond   float64
+}
+
+// StreamSet runs a collection of Streams concurrently against a shared
+// writer, simulating a whole multi-service cluster's combined log firehose.
+type StreamSet struct {
+	Streams []Stream
+	// Out is the shared writer every stream's logger funnels into. Writes
+	// from concurrent streams are serialized so lines are never interleaved
+	// mid-write.
+	Out io.Writer
+}
+
+// Run launches one goroutine per Stream, each emitting at its configured
+// LinesPerSecond until ctx is done, and returns per-stream per-level counts
+// keyed by Stream.Name, along with any per-stream setup errors (e.g. a
+// stream's Ratios not summing to 100) so a misconfigured stream's zero
+// throughput is debuggable rather than silent.
+func (s StreamSet) Run(ctx context.Context) (map[string]map[int64]int64, map[string][]error) {
+	funnel := &funnelWriter{out: s.Out}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := make(map[string]map[int64]int64, len(s.Streams))
+	errs := make(map[string][]error)
+
+	for _, stream := range s.Streams {
+		stream := stream
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counts, streamErrs := runStream(ctx, stream, funnel)
+			mu.Lock()
+			results[stream.Name] = counts
+			if len(streamErrs) != 0 {
+				errs[stream.Name] = streamErrs
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results, errs
+}
+
+// runStream emits log lines for a single Stream at its configured
+// LinesPerSecond until ctx is done, returning per-level counts and any setup
+// errors (in which case no lines are emitted).
+func runStream(ctx context.Context, stream Stream, out io.Writer) (map[int64]int64, []error) {
+	counts := make(map[int64]int64, 6)
+
+	generator, errs := New(map[int64]bool{}, stream.Ratios)
+	if len(errs) != 0 {
+		return counts, errs
+	}
+	generator.Logger.Out = out
+	generator.Labels = stream.Labels
+	if stream.MessageTemplates != nil {
+		generator.MessageTemplates = stream.MessageTemplates
+	}
+	ranges := generator.DeriveDistributionRanges()
+
+	seed := rand.NewSource(time.Now().UnixNano())
+	rander := rand.New(seed)
+
+	interval := time.Second
+	if stream.LinesPerSecond > 0 {
+		interval = time.Duration(float64(time.Second) / stream.LinesPerSecond)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return counts, nil
+		case <-ticker.C:
+			level, ok := pickLevel(ranges, rander)
+			if !ok {
+				continue
+			}
+			counts[level]++
+			generator.emit(level)
+		}
+	}
+}
+
+// funnelWriter serializes concurrent Write calls from multiple streams'
+// loggers onto a single underlying writer so lines from different goroutines
+// are never interleaved mid-write.
+type funnelWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (f *funnelWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.out.Write(p)
+}
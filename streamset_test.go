@@ -0,0 +1,91 @@
+package loggenerator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StreamSet_Run_emitsLabeledLinesPerStream(t *testing.T) {
+	var out bytes.Buffer
+
+	streamSet := StreamSet{
+		Out: &out,
+		Streams: []Stream{
+			{
+				Name:           "checkout",
+				Labels:         map[string]string{"service": "checkout", "instance": "pod-3"},
+				Ratios:         LevelRatios{decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(100), decimal.NewFromInt(0), decimal.NewFromInt(0)},
+				LinesPerSecond: 200,
+			},
+			{
+				Name:           "billing",
+				Labels:         map[string]string{"service": "billing", "instance": "pod-1"},
+				Ratios:         LevelRatios{decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(100), decimal.NewFromInt(0), decimal.NewFromInt(0)},
+				LinesPerSecond: 200,
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	counts, errs := streamSet.Run(ctx)
+
+	assert.Empty(t, errs)
+	assert.Greater(t, counts["checkout"][LvlInfo], int64(0))
+	assert.Greater(t, counts["billing"][LvlInfo], int64(0))
+
+	output := out.String()
+	assert.True(t, strings.Contains(output, `service=checkout`))
+	assert.True(t, strings.Contains(output, `service=billing`))
+}
+
+func Test_StreamSet_Run_stopsOnContextCancel(t *testing.T) {
+	var out bytes.Buffer
+
+	streamSet := StreamSet{
+		Out: &out,
+		Streams: []Stream{
+			{
+				Name:           "api",
+				Ratios:         LevelRatios{decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(100), decimal.NewFromInt(0), decimal.NewFromInt(0)},
+				LinesPerSecond: 1000,
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	counts, errs := streamSet.Run(ctx)
+	assert.Empty(t, errs)
+	assert.EqualValues(t, 0, counts["api"][LvlInfo])
+}
+
+func Test_StreamSet_Run_surfacesPerStreamSetupErrors(t *testing.T) {
+	var out bytes.Buffer
+
+	streamSet := StreamSet{
+		Out: &out,
+		Streams: []Stream{
+			{
+				Name:   "misconfigured",
+				Ratios: LevelRatios{decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(50), decimal.NewFromInt(0), decimal.NewFromInt(0)},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	counts, errs := streamSet.Run(ctx)
+
+	assert.NotEmpty(t, errs["misconfigured"])
+	assert.EqualValues(t, 0, counts["misconfigured"][LvlInfo])
+}
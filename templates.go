@@ -0,0 +1,167 @@
+package loggenerator
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"fmt"
+	"math/rand"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FieldGen produces a single named field's value at emit time.
+type FieldGen func() interface{}
+
+// Template pairs a text/template message body with the named field generators
+// used to fill both the rendered message and the structured log fields passed
+// to logrus.WithFields.
+type Template struct {
+	Text   string
+	Fields map[string]FieldGen
+}
+
+// Render evaluates the template's field generators and renders Text against them,
+// returning the rendered message and the generated fields.
+func (t Template) Render() (string, logrus.Fields) {
+	values := make(map[string]interface{}, len(t.Fields))
+	for name, gen := range t.Fields {
+		values[name] = gen()
+	}
+
+	message := t.Text
+	tmpl, err := template.New("message").Parse(t.Text)
+	if err == nil {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, values); err == nil {
+			message = buf.String()
+		}
+	}
+	return message, logrus.Fields(values)
+}
+
+// RandInt returns a FieldGen producing a random integer in [min, max].
+func RandInt(min, max int) FieldGen {
+	return func() interface{} {
+		return min + rand.Intn(max-min+1)
+	}
+}
+
+// RandChoice returns a FieldGen picking uniformly from choices.
+func RandChoice(choices []string) FieldGen {
+	return func() interface{} {
+		return choices[rand.Intn(len(choices))]
+	}
+}
+
+// UUID returns a FieldGen producing a random RFC 4122 version 4 UUID string.
+func UUID() FieldGen {
+	return func() interface{} {
+		buf := make([]byte, 16)
+		_, _ = crand.Read(buf)
+		buf[6] = (buf[6] & 0x0f) | 0x40
+		buf[8] = (buf[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:16])
+	}
+}
+
+// IPv4 returns a FieldGen producing a random dotted-quad IPv4 address.
+func IPv4() FieldGen {
+	return func() interface{} {
+		return fmt.Sprintf("%d.%d.%d.%d", rand.Intn(256), rand.Intn(256), rand.Intn(256), rand.Intn(256))
+	}
+}
+
+// Duration returns a FieldGen producing a random duration in [min, max], rendered
+// as its String() form (e.g. "142ms").
+func Duration(min, max time.Duration) FieldGen {
+	return func() interface{} {
+		span := int64(max - min)
+		if span <= 0 {
+			return min.String()
+		}
+		return (min + time.Duration(rand.Int63n(span+1))).String()
+	}
+}
+
+// Timestamp returns a FieldGen producing the current time formatted RFC3339Nano.
+func Timestamp() FieldGen {
+	return func() interface{} {
+		return time.Now().Format(time.RFC3339Nano)
+	}
+}
+
+var httpStatuses = []int{200, 201, 204, 301, 302, 400, 401, 403, 404, 409, 422, 500, 502, 503}
+
+// HTTPStatus returns a FieldGen picking from a representative spread of HTTP status codes.
+func HTTPStatus() FieldGen {
+	return func() interface{} {
+		return httpStatuses[rand.Intn(len(httpStatuses))]
+	}
+}
+
+// defaultMessages are the literal, untemplated messages used for any level with
+// no configured Template, preserving the generator's original behavior.
+var defaultMessages = map[int64]string{
+	LvlFatal: "fatal level message",
+	LvlError: "error level message",
+	LvlWarn:  "warn level message",
+	LvlInfo:  "info level message",
+	LvlDebug: "debug level message",
+	LvlTrace: "trace level message",
+}
+
+// defaultTemplates is the built-in message corpus (HTTP access, DB query, auth,
+// background job) wired up in New so structured output works with no setup.
+func defaultTemplates() map[int64][]Template {
+	return map[int64][]Template{
+		LvlInfo: {
+			{
+				Text: "{{.method}} {{.path}} {{.status}} {{.latency}}",
+				Fields: map[string]FieldGen{
+					"method":  RandChoice([]string{"GET", "POST", "PUT", "DELETE"}),
+					"path":    RandChoice([]string{"/api/users", "/api/orders", "/api/health", "/api/login"}),
+					"status":  HTTPStatus(),
+					"latency": Duration(time.Millisecond, 500*time.Millisecond),
+				},
+			},
+			{
+				Text: "background job {{.job}} completed in {{.duration}}",
+				Fields: map[string]FieldGen{
+					"job":      RandChoice([]string{"email-digest", "report-export", "cache-warm", "cleanup"}),
+					"duration": Duration(time.Millisecond, 5*time.Second),
+				},
+			},
+		},
+		LvlDebug: {
+			{
+				Text: "query {{.query}} returned {{.rows}} rows in {{.duration}}",
+				Fields: map[string]FieldGen{
+					"query":    RandChoice([]string{"SELECT * FROM users", "SELECT * FROM orders", "UPDATE sessions SET last_seen"}),
+					"rows":     RandInt(0, 500),
+					"duration": Duration(100*time.Microsecond, 200*time.Millisecond),
+				},
+			},
+		},
+		LvlWarn: {
+			{
+				Text: "auth attempt for {{.user}} from {{.ip}} failed",
+				Fields: map[string]FieldGen{
+					"user": RandChoice([]string{"alice", "bob", "carol", "dave"}),
+					"ip":   IPv4(),
+				},
+			},
+		},
+		LvlError: {
+			{
+				Text: "request {{.request_id}} to {{.path}} failed with status {{.status}}",
+				Fields: map[string]FieldGen{
+					"request_id": UUID(),
+					"path":       RandChoice([]string{"/api/users", "/api/orders", "/api/payments"}),
+					"status":     HTTPStatus(),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package loggenerator
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Template_Render(t *testing.T) {
+	tmpl := Template{
+		Text: "{{.method}} {{.path}} returned {{.status}}",
+		Fields: map[string]FieldGen{
+			"method": RandChoice([]string{"GET"}),
+			"path":   RandChoice([]string{"/api/health"}),
+			"status": RandInt(500, 500),
+		},
+	}
+
+	message, fields := tmpl.Render()
+	assert.Equal(t, "GET /api/health returned 500", message)
+	assert.Equal(t, "GET", fields["method"])
+	assert.Equal(t, "/api/health", fields["path"])
+	assert.Equal(t, 500, fields["status"])
+}
+
+func Test_NewJSON_setsFormatter(t *testing.T) {
+	generator, errs := NewJSON(map[int64]bool{}, LevelRatios{
+		decimal.NewFromInt(0), decimal.NewFromInt(10), decimal.NewFromInt(20),
+		decimal.NewFromInt(50), decimal.NewFromInt(20), decimal.NewFromInt(0),
+	})
+	assert.Len(t, errs, 0)
+	_, ok := generator.Logger.Formatter.(*logrus.JSONFormatter)
+	assert.True(t, ok)
+}